@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ArgType identifies how a raw argument should be parsed and, for slash
+// commands, which discordgo.ApplicationCommandOptionType it maps to.
+type ArgType int
+
+const (
+	ArgString ArgType = iota
+	ArgInt
+	ArgUser
+	ArgChannel
+	ArgRole
+	ArgDuration
+)
+
+// Argument describes a single named, typed parameter accepted by a
+// command or subcommand.
+type Argument struct {
+	Name        string
+	Description string
+	Type        ArgType
+	Required    bool
+
+	// Autocomplete, if set, is called with the user's partial input over
+	// the slash-command path and returns suggested values (max 25, per
+	// Discord's limit).
+	Autocomplete func(input string) []string
+}
+
+// optionType maps an Argument's ArgType to the matching slash-command
+// option type. User/channel/role/duration arguments all surface as
+// strings over the prefix path, but as their native mention/string types
+// over the slash path where Discord can resolve them for us.
+func (a Argument) optionType() discordgo.ApplicationCommandOptionType {
+	switch a.Type {
+	case ArgInt:
+		return discordgo.ApplicationCommandOptionInteger
+	case ArgUser:
+		return discordgo.ApplicationCommandOptionUser
+	case ArgChannel:
+		return discordgo.ApplicationCommandOptionChannel
+	case ArgRole:
+		return discordgo.ApplicationCommandOptionRole
+	default:
+		return discordgo.ApplicationCommandOptionString
+	}
+}
+
+// Args holds the parsed, validated values for a single invocation, keyed
+// by argument name.
+type Args map[string]any
+
+func (a Args) String(name string) string {
+	v, _ := a[name].(string)
+	return v
+}
+
+func (a Args) Int(name string) int {
+	v, _ := a[name].(int)
+	return v
+}
+
+func (a Args) Duration(name string) time.Duration {
+	v, _ := a[name].(time.Duration)
+	return v
+}
+
+var mentionRe = regexp.MustCompile(`^<(@!?|#|@&)(\d+)>$`)
+
+// validate parses and type-checks a raw string token (from the prefix
+// path) against the argument's declared type, returning the typed value
+// stored in Args.
+func validate(arg Argument, raw string) (any, error) {
+	switch arg.Type {
+	case ArgString:
+		return raw, nil
+	case ArgInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a whole number", arg.Name)
+		}
+		return n, nil
+	case ArgUser, ArgChannel, ArgRole:
+		m := mentionRe.FindStringSubmatch(raw)
+		if m == nil {
+			return nil, fmt.Errorf("%s must be a mention", arg.Name)
+		}
+		return m[2], nil
+	case ArgDuration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a duration like 10m or 1h30m", arg.Name)
+		}
+		return d, nil
+	default:
+		return raw, nil
+	}
+}
+
+// parsePrefixArgs validates raw tokens from a prefixed message against
+// the declared arguments, in order. The last argument, if a plain
+// string, greedily consumes every remaining token so commands like
+// "!echo hello there" see "hello there" rather than just "hello".
+func parsePrefixArgs(args []Argument, tokens []string) (Args, error) {
+	parsed := make(Args, len(args))
+	for i, arg := range args {
+		if i >= len(tokens) {
+			if arg.Required {
+				return nil, fmt.Errorf("missing required argument: %s", arg.Name)
+			}
+			continue
+		}
+
+		raw := tokens[i]
+		if i == len(args)-1 && arg.Type == ArgString {
+			raw = strings.Join(tokens[i:], " ")
+		}
+
+		v, err := validate(arg, raw)
+		if err != nil {
+			return nil, err
+		}
+		parsed[arg.Name] = v
+	}
+	return parsed, nil
+}
+
+// parseInteractionArgs reads resolved option values from a slash-command
+// invocation.
+func parseInteractionArgs(args []Argument, opts []*discordgo.ApplicationCommandInteractionDataOption) Args {
+	parsed := make(Args, len(args))
+	for _, opt := range opts {
+		for _, arg := range args {
+			if arg.Name != opt.Name {
+				continue
+			}
+			switch arg.Type {
+			case ArgInt:
+				parsed[arg.Name] = int(opt.IntValue())
+			case ArgUser:
+				parsed[arg.Name] = opt.UserValue(nil).ID
+			case ArgChannel:
+				parsed[arg.Name] = opt.ChannelValue(nil).ID
+			case ArgRole:
+				parsed[arg.Name] = opt.RoleValue(nil, "").ID
+			case ArgDuration:
+				d, _ := time.ParseDuration(opt.StringValue())
+				parsed[arg.Name] = d
+			default:
+				parsed[arg.Name] = opt.StringValue()
+			}
+		}
+	}
+	return parsed
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Handler services a single command invocation through the unified
+// Context, regardless of whether it arrived as a message or interaction.
+type Handler func(ctx *Context) error
+
+// Permission gates a command behind a guild permission bit (e.g.
+// discordgo.PermissionManageServer). Zero means no restriction.
+type Permission int64
+
+// Command is the single declaration a feature package registers for a
+// command: its name, description, arguments, permission, aliases, and
+// the handler that services both the prefix and slash paths. Subcommands
+// let a command expose a tree (e.g. "!config get").
+type Command struct {
+	Name        string
+	Description string
+	Aliases     []string
+	Args        []Argument
+	Subcommands []Command
+	Permission  Permission
+	GuildOnly   bool
+	Handler     Handler
+
+	// Examples are full invocation strings (e.g. "!echo hello there")
+	// shown by the help system.
+	Examples []string
+
+	// Middleware is appended to the registry's global chain for this
+	// command only, so it runs after the global middleware, first entry
+	// first.
+	Middleware []Middleware
+}
+
+// Usage renders the command's invocation signature, e.g.
+// "ping" or "echo <message>". A command with subcommands lists only
+// their names instead, e.g. "config <get|set>"; their arguments are not
+// shown.
+func (c Command) Usage() string {
+	usage := c.Name
+	for _, a := range c.Args {
+		if a.Required {
+			usage += fmt.Sprintf(" <%s>", a.Name)
+		} else {
+			usage += fmt.Sprintf(" [%s]", a.Name)
+		}
+	}
+	if len(c.Subcommands) == 0 {
+		return usage
+	}
+	names := make([]string, len(c.Subcommands))
+	for i, sc := range c.Subcommands {
+		names[i] = sc.Name
+	}
+	return fmt.Sprintf("%s <%s>", c.Name, strings.Join(names, "|"))
+}
+
+// subcommand returns the Subcommands entry matching name, if any.
+func (c Command) subcommand(name string) (Command, bool) {
+	for _, sc := range c.Subcommands {
+		if sc.Name == name {
+			return sc, true
+		}
+	}
+	return Command{}, false
+}
+
+// slashOptions builds the discordgo options for this command, one level
+// of subcommands deep, mapping each Argument to its matching option type.
+// Subcommands nested inside a subcommand are not included.
+func (c Command) slashOptions() []*discordgo.ApplicationCommandOption {
+	if len(c.Subcommands) > 0 {
+		opts := make([]*discordgo.ApplicationCommandOption, 0, len(c.Subcommands))
+		for _, sc := range c.Subcommands {
+			opts = append(opts, &discordgo.ApplicationCommandOption{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        sc.Name,
+				Description: sc.Description,
+				Options:     sc.argOptions(),
+			})
+		}
+		return opts
+	}
+	return c.argOptions()
+}
+
+func (c Command) argOptions() []*discordgo.ApplicationCommandOption {
+	if len(c.Args) == 0 {
+		return nil
+	}
+	opts := make([]*discordgo.ApplicationCommandOption, 0, len(c.Args))
+	for _, a := range c.Args {
+		opts = append(opts, &discordgo.ApplicationCommandOption{
+			Type:         a.optionType(),
+			Name:         a.Name,
+			Description:  a.Description,
+			Required:     a.Required,
+			Autocomplete: a.Autocomplete != nil,
+		})
+	}
+	return opts
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"github.com/bwmarrin/discordgo"
+)
+
+// Context abstracts a command invocation that originated either from a
+// prefixed message or from a slash-command interaction, so a single
+// handler can service both paths.
+type Context struct {
+	Session *discordgo.Session
+
+	Message     *discordgo.MessageCreate
+	Interaction *discordgo.InteractionCreate
+
+	GuildID   string
+	ChannelID string
+	Author    *discordgo.User
+
+	// Command is the resolved command (and, if applicable, subcommand)
+	// being invoked.
+	Command *Command
+	Args    Args
+
+	responded bool
+}
+
+// IsInteraction reports whether this invocation came from a slash command.
+func (c *Context) IsInteraction() bool {
+	return c.Interaction != nil
+}
+
+// Reply sends content back to the user on whichever path triggered the
+// command. For interactions, the first reply is the initial response and
+// later replies are sent as follow-up messages.
+func (c *Context) Reply(content string) error {
+	if c.IsInteraction() {
+		if !c.responded {
+			c.responded = true
+			return c.Session.InteractionRespond(c.Interaction.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{Content: content},
+			})
+		}
+		_, err := c.Session.FollowupMessageCreate(c.Interaction.Interaction, true, &discordgo.WebhookParams{Content: content})
+		return err
+	}
+	_, err := c.Session.ChannelMessageSend(c.ChannelID, content)
+	return err
+}
+
+// ReplyEmbed sends an embed back to the user on whichever path triggered
+// the command.
+func (c *Context) ReplyEmbed(embed *discordgo.MessageEmbed) error {
+	if c.IsInteraction() {
+		if !c.responded {
+			c.responded = true
+			return c.Session.InteractionRespond(c.Interaction.Interaction, &discordgo.InteractionResponse{
+				Type: discordgo.InteractionResponseChannelMessageWithSource,
+				Data: &discordgo.InteractionResponseData{Embeds: []*discordgo.MessageEmbed{embed}},
+			})
+		}
+		_, err := c.Session.FollowupMessageCreate(c.Interaction.Interaction, true, &discordgo.WebhookParams{Embeds: []*discordgo.MessageEmbed{embed}})
+		return err
+	}
+	_, err := c.Session.ChannelMessageSendEmbed(c.ChannelID, embed)
+	return err
+}
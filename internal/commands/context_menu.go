@@ -0,0 +1,89 @@
+package commands
+
+import "github.com/bwmarrin/discordgo"
+
+// UserHandler services a USER context-menu command, receiving the
+// resolved target user.
+type UserHandler func(ctx *Context, target *discordgo.User) error
+
+// MessageHandler services a MESSAGE context-menu command, receiving the
+// resolved target message.
+type MessageHandler func(ctx *Context, target *discordgo.Message) error
+
+// contextCommand is the internal bookkeeping entry for a registered
+// context-menu command.
+type contextCommand struct {
+	name           string
+	cmdType        discordgo.ApplicationCommandType
+	userHandler    UserHandler
+	messageHandler MessageHandler
+}
+
+// RegisterUserCommand declares a "USER" application command (shown in
+// Discord's right-click "Apps" menu on a member) that resolves its
+// target to a *discordgo.User before invoking handler.
+func (r *Registry) RegisterUserCommand(name string, handler UserHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contextCommands = append(r.contextCommands, contextCommand{
+		name:        name,
+		cmdType:     discordgo.UserApplicationCommand,
+		userHandler: handler,
+	})
+}
+
+// RegisterMessageCommand declares a "MESSAGE" application command (shown
+// in Discord's right-click "Apps" menu on a message) that resolves its
+// target to a *discordgo.Message before invoking handler.
+func (r *Registry) RegisterMessageCommand(name string, handler MessageHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contextCommands = append(r.contextCommands, contextCommand{
+		name:           name,
+		cmdType:        discordgo.MessageApplicationCommand,
+		messageHandler: handler,
+	})
+}
+
+func (r *Registry) lookupContextCommand(name string, cmdType discordgo.ApplicationCommandType) (contextCommand, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, cc := range r.contextCommands {
+		if cc.name == name && cc.cmdType == cmdType {
+			return cc, true
+		}
+	}
+	return contextCommand{}, false
+}
+
+func (r *Registry) handleContextMenu(s *discordgo.Session, i *discordgo.InteractionCreate, data discordgo.ApplicationCommandInteractionData) {
+	cc, ok := r.lookupContextCommand(data.Name, data.CommandType)
+	if !ok {
+		return
+	}
+
+	var author *discordgo.User
+	if i.Member != nil {
+		author = i.Member.User
+	} else {
+		author = i.User
+	}
+	ctx := &Context{
+		Session:     s,
+		Interaction: i,
+		GuildID:     i.GuildID,
+		ChannelID:   i.ChannelID,
+		Author:      author,
+	}
+
+	var err error
+	switch data.CommandType {
+	case discordgo.UserApplicationCommand:
+		err = cc.userHandler(ctx, data.Resolved.Users[data.TargetID])
+	case discordgo.MessageApplicationCommand:
+		err = cc.messageHandler(ctx, data.Resolved.Messages[data.TargetID])
+	}
+	if err != nil {
+		ctx.Reply("Something went wrong: " + err.Error())
+	}
+}
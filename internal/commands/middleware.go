@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Middleware wraps a Handler, running before (and optionally after) the
+// wrapped handler. Returning an error without calling next short-circuits
+// the chain.
+type Middleware func(next Handler) Handler
+
+// chain composes middleware innermost-last so that the first entry runs
+// first, mirroring the order callers declare them in.
+func chain(mw []Middleware, final Handler) Handler {
+	h := final
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+	return h
+}
+
+// Logging logs every invocation with its command name and author.
+func Logging() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx *Context) error {
+			log.Printf("[%s] %s#%s invoked %s", time.Now().Format("2006-01-02 15:04:05"), ctx.Author.Username, ctx.Author.Discriminator, ctx.Command.Name)
+			return next(ctx)
+		}
+	}
+}
+
+// GuildOnly rejects the invocation if it did not occur inside a guild.
+func GuildOnly() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx *Context) error {
+			if ctx.GuildID == "" {
+				return ctx.Reply("This command can only be used in a server.")
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// Cooldown rejects invocations of a command by the same user within the
+// given interval of their last successful invocation of that command.
+func Cooldown(interval time.Duration) Middleware {
+	var mu sync.Mutex
+	last := make(map[string]time.Time)
+
+	return func(next Handler) Handler {
+		return func(ctx *Context) error {
+			mu.Lock()
+			key := ctx.Author.ID + ":" + ctx.Command.Name
+			if prev, ok := last[key]; ok {
+				if remaining := interval - time.Since(prev); remaining > 0 {
+					mu.Unlock()
+					return ctx.Reply(fmt.Sprintf("Slow down! Try again in %s.", remaining.Round(time.Second)))
+				}
+			}
+			mu.Unlock()
+
+			err := next(ctx)
+			if err == nil {
+				mu.Lock()
+				last[key] = time.Now()
+				mu.Unlock()
+			}
+			return err
+		}
+	}
+}
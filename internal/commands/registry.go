@@ -0,0 +1,314 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const defaultPrefix = "!"
+
+// Registry is the single source of truth for every registered command.
+// Feature packages call Register from their
+// Init(*discordgo.Session, *Registry) function; main wires the registry's handlers into the session and
+// calls Sync to create the slash commands.
+type Registry struct {
+	commands        map[string]*Command
+	contextCommands []contextCommand
+	middleware      []Middleware
+
+	mu      sync.RWMutex
+	prefix  map[string]string // guildID -> prefix override
+	created []string          // application command IDs created by Sync, for cleanup
+}
+
+// NewRegistry creates an empty Registry with the given global middleware
+// chain applied to every command (e.g. Logging, GuildOnly).
+func NewRegistry(mw ...Middleware) *Registry {
+	return &Registry{
+		commands:   make(map[string]*Command),
+		middleware: mw,
+		prefix:     make(map[string]string),
+	}
+}
+
+// Register adds a command to the registry. It panics on duplicate names
+// since that indicates a programming error in a feature package's Init.
+func (r *Registry) Register(cmd Command) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.commands[cmd.Name]; exists {
+		panic("commands: duplicate command registered: " + cmd.Name)
+	}
+	r.commands[cmd.Name] = &cmd
+	for _, alias := range cmd.Aliases {
+		if _, exists := r.commands[alias]; !exists {
+			r.commands[alias] = &cmd
+		}
+	}
+}
+
+// Commands returns every distinctly-named registered command, sorted by
+// name for stable listing (e.g. by a help system).
+func (r *Registry) Commands() []*Command {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	seen := make(map[*Command]bool)
+	out := make([]*Command, 0, len(r.commands))
+	for _, cmd := range r.commands {
+		if !seen[cmd] {
+			seen[cmd] = true
+			out = append(out, cmd)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Lookup finds a registered command by name or alias.
+func (r *Registry) Lookup(name string) (*Command, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cmd, ok := r.commands[name]
+	return cmd, ok
+}
+
+// SetPrefix overrides the command prefix for a single guild. Passing ""
+// resets it to the default.
+func (r *Registry) SetPrefix(guildID, prefix string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if prefix == "" {
+		delete(r.prefix, guildID)
+		return
+	}
+	r.prefix[guildID] = prefix
+}
+
+// PrefixFor returns the effective prefix for a guild, falling back to
+// the default when no override is set (or outside a guild).
+func (r *Registry) PrefixFor(guildID string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if p, ok := r.prefix[guildID]; ok {
+		return p
+	}
+	return defaultPrefix
+}
+
+// Bind wires the registry's message and interaction handlers into the
+// session. Call this once at startup; feature packages should already
+// have registered their commands via Register by this point.
+func (r *Registry) Bind(s *discordgo.Session) {
+	s.AddHandler(r.handleMessage)
+	s.AddHandler(r.handleInteraction)
+}
+
+func (r *Registry) handleMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
+	if m.Author.ID == s.State.User.ID {
+		return
+	}
+
+	prefix := r.PrefixFor(m.GuildID)
+	if !strings.HasPrefix(m.Content, prefix) {
+		return
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(m.Content, prefix))
+	if len(fields) == 0 {
+		return
+	}
+
+	cmd, ok := r.Lookup(fields[0])
+	if !ok {
+		s.ChannelMessageSend(m.ChannelID, fmt.Sprintf("Invalid command. Try %shelp for a list of commands.", prefix))
+		return
+	}
+	tokens := fields[1:]
+
+	active := *cmd
+	if len(cmd.Subcommands) > 0 && len(tokens) > 0 {
+		if sc, ok := cmd.subcommand(tokens[0]); ok {
+			active = sc
+			tokens = tokens[1:]
+		}
+	}
+
+	args, err := parsePrefixArgs(active.Args, tokens)
+	if err != nil {
+		s.ChannelMessageSend(m.ChannelID, err.Error())
+		return
+	}
+
+	ctx := &Context{
+		Session:   s,
+		Message:   m,
+		GuildID:   m.GuildID,
+		ChannelID: m.ChannelID,
+		Author:    m.Author,
+		Command:   &active,
+		Args:      args,
+	}
+	r.dispatch(ctx, active)
+}
+
+func (r *Registry) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		r.handleAutocomplete(s, i)
+		return
+	}
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+	data := i.ApplicationCommandData()
+
+	if data.CommandType == discordgo.UserApplicationCommand || data.CommandType == discordgo.MessageApplicationCommand {
+		r.handleContextMenu(s, i, data)
+		return
+	}
+
+	cmd, ok := r.Lookup(data.Name)
+	if !ok {
+		return
+	}
+
+	active := *cmd
+	opts := data.Options
+	if len(cmd.Subcommands) > 0 && len(opts) > 0 && opts[0].Type == discordgo.ApplicationCommandOptionSubCommand {
+		if sc, ok := cmd.subcommand(opts[0].Name); ok {
+			active = sc
+			opts = opts[0].Options
+		}
+	}
+
+	var author *discordgo.User
+	if i.Member != nil {
+		author = i.Member.User
+	} else {
+		author = i.User
+	}
+
+	ctx := &Context{
+		Session:     s,
+		Interaction: i,
+		GuildID:     i.GuildID,
+		ChannelID:   i.ChannelID,
+		Author:      author,
+		Command:     &active,
+		Args:        parseInteractionArgs(active.Args, opts),
+	}
+	r.dispatch(ctx, active)
+}
+
+func (r *Registry) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ApplicationCommandData()
+	cmd, ok := r.Lookup(data.Name)
+	if !ok {
+		return
+	}
+
+	var focused *discordgo.ApplicationCommandInteractionDataOption
+	for _, opt := range data.Options {
+		if opt.Focused {
+			focused = opt
+			break
+		}
+	}
+	if focused == nil {
+		return
+	}
+
+	for _, arg := range cmd.Args {
+		if arg.Name != focused.Name || arg.Autocomplete == nil {
+			continue
+		}
+		suggestions := arg.Autocomplete(focused.StringValue())
+		choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(suggestions))
+		for _, sugg := range suggestions {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: sugg, Value: sugg})
+		}
+		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+			Data: &discordgo.InteractionResponseData{Choices: choices},
+		})
+		return
+	}
+}
+
+func (r *Registry) dispatch(ctx *Context, cmd Command) {
+	if cmd.Handler == nil {
+		ctx.Reply(fmt.Sprintf("Usage: %s%s", r.PrefixFor(ctx.GuildID), cmd.Usage()))
+		return
+	}
+
+	if cmd.Permission != 0 && ctx.GuildID != "" {
+		perms, err := ctx.Session.State.UserChannelPermissions(ctx.Author.ID, ctx.ChannelID)
+		if err == nil && perms&int64(cmd.Permission) == 0 {
+			ctx.Reply("You don't have permission to use this command.")
+			return
+		}
+	}
+
+	mw := make([]Middleware, 0, len(r.middleware)+len(cmd.Middleware)+1)
+	mw = append(mw, r.middleware...)
+	mw = append(mw, cmd.Middleware...)
+	if cmd.GuildOnly {
+		mw = append(mw, GuildOnly())
+	}
+
+	handler := chain(mw, cmd.Handler)
+	if err := handler(ctx); err != nil {
+		ctx.Reply("Something went wrong: " + err.Error())
+	}
+}
+
+// Sync creates the application (slash and context-menu) commands for
+// every registered command, recording the resulting IDs for later
+// cleanup. guildID may be empty to register globally.
+func (r *Registry) Sync(s *discordgo.Session, guildID string) error {
+	for _, cmd := range r.Commands() {
+		created, err := s.ApplicationCommandCreate(s.State.User.ID, guildID, &discordgo.ApplicationCommand{
+			Name:        cmd.Name,
+			Description: cmd.Description,
+			Options:     cmd.slashOptions(),
+		})
+		if err != nil {
+			return err
+		}
+		r.mu.Lock()
+		r.created = append(r.created, created.ID)
+		r.mu.Unlock()
+	}
+
+	r.mu.RLock()
+	contextCmds := append([]contextCommand(nil), r.contextCommands...)
+	r.mu.RUnlock()
+
+	for _, cc := range contextCmds {
+		created, err := s.ApplicationCommandCreate(s.State.User.ID, guildID, &discordgo.ApplicationCommand{
+			Name: cc.name,
+			Type: cc.cmdType,
+		})
+		if err != nil {
+			return err
+		}
+		r.mu.Lock()
+		r.created = append(r.created, created.ID)
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// Cleanup removes every application command created by Sync.
+func (r *Registry) Cleanup(s *discordgo.Session, guildID string) {
+	r.mu.RLock()
+	ids := append([]string(nil), r.created...)
+	r.mu.RUnlock()
+
+	for _, id := range ids {
+		s.ApplicationCommandDelete(s.State.User.ID, guildID, id)
+	}
+}
@@ -0,0 +1,30 @@
+// Package echo registers the !echo / "/echo" command.
+package echo
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/YoruAkio/GODiscord/internal/commands"
+)
+
+// Init registers the echo command with the registry.
+func Init(s *discordgo.Session, reg *commands.Registry) error {
+	reg.Register(commands.Command{
+		Name:        "echo",
+		Description: "Repeats back the message sent after the command.",
+		Args: []commands.Argument{
+			{Name: "message", Description: "The message to echo", Type: commands.ArgString, Required: true},
+		},
+		Examples: []string{"!echo hello there"},
+		Handler:  handle,
+	})
+	return nil
+}
+
+func handle(ctx *commands.Context) error {
+	content := ctx.Args.String("message")
+	if content == "" {
+		return ctx.Reply("Please provide something to echo!")
+	}
+	return ctx.Reply(content)
+}
@@ -0,0 +1,118 @@
+// Package help registers the !help / "/help" command, rendering an
+// embed sourced entirely from the command registry.
+package help
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/YoruAkio/GODiscord/internal/commands"
+)
+
+// Init registers the help command with the registry.
+func Init(s *discordgo.Session, reg *commands.Registry) error {
+	reg.Register(commands.Command{
+		Name:        "help",
+		Description: "Shows the list of available commands.",
+		Args: []commands.Argument{
+			{
+				Name:         "command",
+				Description:  "A specific command to look up",
+				Type:         commands.ArgString,
+				Autocomplete: commandNames(reg),
+			},
+		},
+		Handler: handler(reg),
+	})
+	return nil
+}
+
+func commandNames(reg *commands.Registry) func(input string) []string {
+	return func(input string) []string {
+		input = strings.ToLower(input)
+		var names []string
+		for _, cmd := range reg.Commands() {
+			if strings.Contains(strings.ToLower(cmd.Name), input) {
+				names = append(names, cmd.Name)
+				if len(names) == 25 {
+					break
+				}
+			}
+		}
+		return names
+	}
+}
+
+func handler(reg *commands.Registry) commands.Handler {
+	return func(ctx *commands.Context) error {
+		if name := ctx.Args.String("command"); name != "" {
+			cmd, ok := reg.Lookup(name)
+			if !ok {
+				return ctx.Reply(fmt.Sprintf("No such command: %s", name))
+			}
+			return ctx.ReplyEmbed(commandEmbed(reg, ctx.GuildID, *cmd))
+		}
+		return ctx.ReplyEmbed(overviewEmbed(reg, ctx.GuildID))
+	}
+}
+
+func overviewEmbed(reg *commands.Registry, guildID string) *discordgo.MessageEmbed {
+	prefix := reg.PrefixFor(guildID)
+	embed := &discordgo.MessageEmbed{
+		Title:       "Commands",
+		Description: fmt.Sprintf("Use `%shelp <command>` for details on a specific command.", prefix),
+		Color:       0x5865F2,
+	}
+	for _, cmd := range reg.Commands() {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%s%s", prefix, cmd.Usage()),
+			Value: cmd.Description,
+		})
+	}
+	return embed
+}
+
+func commandEmbed(reg *commands.Registry, guildID string, cmd commands.Command) *discordgo.MessageEmbed {
+	prefix := reg.PrefixFor(guildID)
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("%s%s", prefix, cmd.Usage()),
+		Description: cmd.Description,
+		Color:       0x5865F2,
+	}
+
+	if len(cmd.Aliases) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Aliases",
+			Value: strings.Join(cmd.Aliases, ", "),
+		})
+	}
+
+	if len(cmd.Subcommands) > 0 {
+		var sb strings.Builder
+		for _, sc := range cmd.Subcommands {
+			fmt.Fprintf(&sb, "`%s%s %s` - %s\n", prefix, cmd.Name, sc.Usage(), sc.Description)
+		}
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Subcommands",
+			Value: sb.String(),
+		})
+	}
+
+	if cmd.Permission != 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Required Permission",
+			Value: fmt.Sprintf("`%d`", cmd.Permission),
+		})
+	}
+
+	if len(cmd.Examples) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  "Examples",
+			Value: "`" + strings.Join(cmd.Examples, "`\n`") + "`",
+		})
+	}
+
+	return embed
+}
@@ -0,0 +1,163 @@
+// Package media auto-reposts video links as native Discord attachments.
+//
+// When a message contains a ||spoiler||-wrapped link to a supported video
+// host, or an already-posted link receives the trigger reaction, the
+// linked media is downloaded and re-uploaded as an attachment (replacing
+// the original message) so viewers get an inline player instead of
+// leaving Discord.
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/wader/goutubedl"
+)
+
+// TriggerEmoji is the reaction that requests a repost for a link that
+// wasn't originally posted inside a spoiler block.
+const TriggerEmoji = "🔽"
+
+const downloadTimeout = 2 * time.Minute
+
+// urlRe is a pragmatic, xurls-style bare-URL matcher; it does not aim to
+// validate every RFC 3986 edge case, only to find plausible links inside
+// message content.
+var urlRe = regexp.MustCompile(`https?://[^\s<>|]+`)
+
+var spoilerRe = regexp.MustCompile(`\|\|([^|]+)\|\|`)
+
+// videoHostRe matches the hosts we know how to hand off to goutubedl.
+var videoHostRe = regexp.MustCompile(`(?i)^https?://(www\.|m\.|vm\.)?(youtube\.com|youtu\.be|twitter\.com|x\.com|tiktok\.com|reddit\.com)/`)
+
+// inFlight tracks messages currently being reposted, so a message-create
+// and a reaction (or two overlapping reactions) on the same message
+// can't both trigger a duplicate download and upload.
+var inFlight sync.Map // messageID -> struct{}
+
+// Init registers the media handlers on the session. It does not check
+// whether the feature is enabled; callers only call it when the
+// deployment enables it.
+func Init(s *discordgo.Session) error {
+	s.AddHandler(handleMessage)
+	s.AddHandler(handleReactionAdd)
+	return nil
+}
+
+func handleMessage(s *discordgo.Session, m *discordgo.MessageCreate) {
+	if m.Author.ID == s.State.User.ID {
+		return
+	}
+
+	for _, spoiler := range spoilerRe.FindAllStringSubmatch(m.Content, -1) {
+		url := urlRe.FindString(spoiler[1])
+		if url == "" || !videoHostRe.MatchString(url) {
+			continue
+		}
+		repost(s, m.GuildID, m.ChannelID, m.ID, url)
+		return
+	}
+}
+
+func handleReactionAdd(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	if r.Emoji.Name != TriggerEmoji || r.UserID == s.State.User.ID {
+		return
+	}
+
+	msg, err := s.ChannelMessage(r.ChannelID, r.MessageID)
+	if err != nil {
+		if restErr, ok := err.(*discordgo.RESTError); ok && restErr.Response != nil && restErr.Response.StatusCode == 404 {
+			// The message was already reposted (and deleted) by an earlier
+			// reaction; a late or duplicate 🔽 reaction references a stale ID.
+			return
+		}
+		log.Printf("media: fetching reacted message: %v", err)
+		return
+	}
+
+	url := urlRe.FindString(msg.Content)
+	if url == "" || !videoHostRe.MatchString(url) {
+		return
+	}
+	repost(s, r.GuildID, r.ChannelID, r.MessageID, url)
+}
+
+// repost downloads the media at url and uploads it as an attachment in
+// place of the original message, provided it fits under the guild's
+// upload limit.
+func repost(s *discordgo.Session, guildID, channelID, messageID, url string) {
+	if _, alreadyRunning := inFlight.LoadOrStore(messageID, struct{}{}); alreadyRunning {
+		return
+	}
+	defer inFlight.Delete(messageID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	defer cancel()
+
+	info, err := goutubedl.New(ctx, url, goutubedl.Options{})
+	if err != nil {
+		log.Printf("media: probing %s: %v", url, err)
+		return
+	}
+
+	dl, err := info.Download(ctx, "best")
+	if err != nil {
+		log.Printf("media: downloading %s: %v", url, err)
+		return
+	}
+	defer dl.Close()
+
+	limit := uploadLimit(s, guildID)
+	data, err := io.ReadAll(io.LimitReader(dl, limit+1))
+	if err != nil {
+		log.Printf("media: reading download for %s: %v", url, err)
+		return
+	}
+	if int64(len(data)) > limit {
+		log.Printf("media: %s exceeds the %d byte upload limit for this guild, leaving as a link", url, limit)
+		return
+	}
+
+	filename := fmt.Sprintf("%s.mp4", info.Info.ID)
+	_, err = s.ChannelFileSend(channelID, filename, bytes.NewReader(data))
+	if err != nil {
+		log.Printf("media: uploading %s: %v", url, err)
+		return
+	}
+
+	if err := s.ChannelMessageDelete(channelID, messageID); err != nil {
+		log.Printf("media: deleting original message: %v", err)
+	}
+}
+
+// uploadLimit returns the byte ceiling Discord enforces for file uploads
+// in the given guild, based on its boost tier. Guild lookups that fail
+// (e.g. DMs) fall back to the default, unboosted limit.
+func uploadLimit(s *discordgo.Session, guildID string) int64 {
+	const (
+		defaultLimit = 8 << 20
+		tier2Limit   = 50 << 20
+		tier3Limit   = 100 << 20
+	)
+
+	guild, err := s.State.Guild(guildID)
+	if err != nil {
+		return defaultLimit
+	}
+
+	switch guild.PremiumTier {
+	case discordgo.PremiumTier2:
+		return tier2Limit
+	case discordgo.PremiumTier3:
+		return tier3Limit
+	default:
+		return defaultLimit
+	}
+}
@@ -0,0 +1,40 @@
+// Package ping registers the !ping / "/ping" command.
+package ping
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/YoruAkio/GODiscord/internal/commands"
+)
+
+// Init registers the ping command with the registry.
+func Init(s *discordgo.Session, reg *commands.Registry) error {
+	reg.Register(commands.Command{
+		Name:        "ping",
+		Description: "Replies with 'Pong!' and shows client WebSocket ping, plus response time for !ping.",
+		Handler:     handle,
+	})
+	return nil
+}
+
+func handle(ctx *commands.Context) error {
+	wsLatency := ctx.Session.HeartbeatLatency().Round(time.Millisecond)
+
+	if ctx.IsInteraction() {
+		content := fmt.Sprintf("Pong! WebSocket Ping: %s", wsLatency)
+		return ctx.Reply(content)
+	}
+
+	start := time.Now()
+	msg, err := ctx.Session.ChannelMessageSend(ctx.ChannelID, "Pong!")
+	if err != nil {
+		return err
+	}
+	elapsed := time.Since(start)
+	content := fmt.Sprintf("Pong! Response Time: %s | WebSocket Ping: %s", elapsed, wsLatency)
+	_, err = ctx.Session.ChannelMessageEdit(msg.ChannelID, msg.ID, content)
+	return err
+}
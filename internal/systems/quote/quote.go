@@ -0,0 +1,39 @@
+// Package quote registers the "Quote" message command and the
+// "User Info" user command, Discord's right-click context-menu commands.
+package quote
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"github.com/YoruAkio/GODiscord/internal/commands"
+)
+
+// Init registers the context-menu commands with the registry.
+func Init(s *discordgo.Session, reg *commands.Registry) error {
+	reg.RegisterMessageCommand("Quote", quote)
+	reg.RegisterUserCommand("User Info", userInfo)
+	return nil
+}
+
+func quote(ctx *commands.Context, target *discordgo.Message) error {
+	embed := &discordgo.MessageEmbed{
+		Description: target.Content,
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    target.Author.Username,
+			IconURL: target.Author.AvatarURL(""),
+		},
+		Timestamp: string(target.Timestamp.Format("2006-01-02T15:04:05Z07:00")),
+	}
+	return ctx.ReplyEmbed(embed)
+}
+
+func userInfo(ctx *commands.Context, target *discordgo.User) error {
+	created, err := discordgo.SnowflakeTimestamp(target.ID)
+	if err != nil {
+		return err
+	}
+	content := fmt.Sprintf("**%s**\nID: %s\nAccount created: %s", target.Username, target.ID, created.Format("2006-01-02"))
+	return ctx.Reply(content)
+}